@@ -1,54 +1,90 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
+	"math/bits"
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/ashtishad/log-analyzer/processor"
 	"github.com/ashtishad/log-analyzer/seed"
 )
 
-// LogInfo represents a single log entry with user activity data.
-type LogInfo struct {
-	UserID    int64     `json:"userId"`
-	PageName  string    `json:"pageName"`
-	Timestamp time.Time `json:"timestamp"`
-}
-
 const (
-	minLoyalPages = 4
-	timeout       = 1000 * time.Millisecond
+	minLoyalPages        = 4
+	minLoyalDays         = 2
+	timeout              = 1000 * time.Millisecond
+	dateLayout           = "2006-01-02"
+	followReportInterval = 1 * time.Second
 )
 
 // File format: Timestamp, PageId, CustomerId
 // every day new file(each has 10000 log entries)
 // find "loyal customers" from 10000 users.
-// (a) they came on both days.
-// (b) they visited at least 4 unique pages.
+// (a) they visited on at least `min-days` distinct days in the range.
+// (b) they visited at least `min-pages` unique pages across the range.
 func main() {
-	if err := seed.GenerateLogFiles(); err != nil {
+	pattern := flag.String("pattern", filepath.Join("seed", "files", "logs_%Y-%m-%d.log"), "strftime-style log file name template (%Y, %m, %d, %H)")
+	fromFlag := flag.String("from", "2024-10-01", "start date of the range, inclusive (YYYY-MM-DD)")
+	toFlag := flag.String("to", "2024-10-02", "end date of the range, inclusive (YYYY-MM-DD)")
+	minDays := flag.Int("min-days", minLoyalDays, "minimum number of distinct days a user must visit on")
+	minPages := flag.Int("min-pages", minLoyalPages, "minimum number of unique pages a user must visit")
+	skipMissing := flag.Bool("skip-missing", false, "treat a missing log file for a date as a soft error instead of failing the run")
+	followFlag := flag.Bool("follow", false, "run in live follow mode instead of a one-shot batch analysis: tail -pattern and continuously report loyal customers over a sliding window")
+	followWindowDays := flag.Int("follow-window-days", minLoyalDays, "size of the sliding window (in days) follow mode reports loyal customers over")
+	followDuration := flag.Duration("follow-duration", 30*time.Second, "how long follow mode runs before exiting")
+	flag.Parse()
+
+	from, err := time.Parse(dateLayout, *fromFlag)
+	if err != nil {
+		fmt.Println("invalid -from date:", err)
+		return
+	}
+
+	to, err := time.Parse(dateLayout, *toFlag)
+	if err != nil {
+		fmt.Println("invalid -to date:", err)
+		return
+	}
+
+	// Seed exactly the range about to be analyzed, using the same pattern,
+	// so a custom -from/-to/-pattern run has matching data on disk instead
+	// of only ever getting the two baked-in demo days.
+	if err := seed.GenerateLogFiles(seed.WithDateRange(from, to), seed.WithPattern(*pattern)); err != nil {
 		slog.Error("failed to generate log files", "err", err)
 		return
 	}
 
+	if *followFlag {
+		ctx, cancel := context.WithTimeout(context.Background(), *followDuration)
+		defer cancel()
+
+		if err := runFollowMode(ctx, *pattern, *followWindowDays, *minDays, *minPages); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			fmt.Println("follow mode failed:", err)
+		}
+
+		return
+	}
+
 	start := time.Now()
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	file1, file2, err := processLogs(ctx)
+	days, err := processLogs(ctx, *pattern, from, to, *skipMissing)
 	if err != nil {
 		fmt.Println("failed to process logs:", err)
 		return
 	}
 
-	loyalCustomers, err := getLoyalCustomers(ctx, file1, file2)
+	loyalCustomers, err := getLoyalCustomers(ctx, days, *minDays, *minPages)
 	if err != nil {
 		fmt.Println("failed to get loyal customers:", err)
 		return
@@ -61,115 +97,135 @@ func main() {
 	fmt.Printf("Loyal Customer IDs: %v\n", loyalCustomers)
 }
 
-// processLogs reads and parses log files for two consecutive days.
-func processLogs(ctx context.Context) ([]LogInfo, []LogInfo, error) {
-	file1, err := readLogFile(ctx, filepath.Join("seed", "files", "logs_2024-10-01.log"))
-	if err != nil {
-		return nil, nil, fmt.Errorf("error reading file 1: %w", err)
-	}
+// runFollowMode pairs processor.Follow with a slidingLoyaltyWindow for
+// continuous reporting: entries tailed from pattern's current file are fed
+// into the window as they arrive, and the current set of loyal customers is
+// printed on every followReportInterval tick until Follow returns (ctx
+// cancelled, its deadline reached, or an unrecoverable read error).
+func runFollowMode(ctx context.Context, pattern string, windowDays, minDays, minPages int) error {
+	out := make(chan processor.LogInfo)
+	errCh := make(chan error, 1)
 
-	file2, err := readLogFile(ctx, filepath.Join("seed", "files", "logs_2024-10-02.log"))
-	if err != nil {
-		return nil, nil, fmt.Errorf("error reading file 2: %w", err)
-	}
+	go func() {
+		errCh <- processor.Follow(ctx, pattern, out)
+	}()
 
-	return file1, file2, nil
-}
+	w := newSlidingLoyaltyWindow(windowDays, minDays, minPages)
 
-// readLogFile reads a log file and returns a slice of LogInfo.
-func readLogFile(ctx context.Context, filename string) ([]LogInfo, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	defer file.Close()
+	ticker := time.NewTicker(followReportInterval)
+	defer ticker.Stop()
 
-	var logs []LogInfo
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
+	for {
 		select {
+		case entry := <-out:
+			w.Add(entry.UserID, entry.PageName, entry.Timestamp)
+		case <-ticker.C:
+			fmt.Printf("Loyal Customer IDs (last %d days): %v\n", windowDays, w.LoyalCustomers())
+		case err := <-errCh:
+			return err
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			var log LogInfo
-
-			if err := json.Unmarshal(scanner.Bytes(), &log); err != nil {
-				return nil, err
-			}
-
-			logs = append(logs, log)
+			return ctx.Err()
 		}
 	}
+}
+
+// processLogs expands pattern for every date in [from, to] and reads each
+// resulting file concurrently via processor.ReadLogFile. The result is
+// ordered by date, one slice of entries per day. A missing file is a hard
+// error unless skipMissingFiles is set, in which case it's logged and that
+// day is left empty.
+func processLogs(ctx context.Context, pattern string, from, to time.Time, skipMissingFiles bool) ([][]processor.LogInfo, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid date range: %s is before %s", to.Format(dateLayout), from.Format(dateLayout))
+	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	var dates []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
 	}
 
-	return logs, nil
-}
+	days := make([][]processor.LogInfo, len(dates))
 
-// getLoyalCustomers identifies loyal customers based on visit frequency and unique page views.
-// Time Complexity: O(n log n), where n is the total number of log entries
-// Space Complexity: O(m), where m is the number of unique users
-func getLoyalCustomers(ctx context.Context, page1, page2 []LogInfo) ([]int64, error) {
-	userPages := make(map[int64]struct {
-		pages     map[string]bool
-		visitDays int
-	})
+	var wg sync.WaitGroup
 
-	processDayLogs := func(logs []LogInfo, day int) error {
-		for _, log := range logs {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-				if _, ok := userPages[log.UserID]; !ok {
-					userPages[log.UserID] = struct {
-						pages     map[string]bool
-						visitDays int
-					}{pages: make(map[string]bool), visitDays: 0}
-				}
+	errCh := make(chan error, len(dates))
+
+	for i, d := range dates {
+		wg.Add(1)
+
+		go func(i int, d time.Time) {
+			defer wg.Done()
 
-				info := userPages[log.UserID]
-				info.pages[log.PageName] = true
+			path := processor.ExpandPattern(pattern, d)
 
-				if info.visitDays < day {
-					info.visitDays = day
+			logs, err := processor.ReadLogFile(ctx, path)
+			if err != nil {
+				if skipMissingFiles && errors.Is(err, os.ErrNotExist) {
+					slog.Warn("skipping missing log file", "path", path, "date", d.Format(dateLayout))
+					return
 				}
 
-				userPages[log.UserID] = info
+				errCh <- fmt.Errorf("error reading file for %s: %w", d.Format(dateLayout), err)
+
+				return
 			}
-		}
 
-		return nil
+			days[i] = logs
+		}(i, d)
 	}
 
-	if err := processDayLogs(page1, 1); err != nil {
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
 		return nil, err
 	}
 
-	if err := processDayLogs(page2, 2); err != nil {
-		return nil, err
+	return days, nil
+}
+
+// getLoyalCustomers identifies loyal customers across an arbitrary number of
+// days: users seen on at least minDays distinct days with at least minPages
+// unique pages across the whole range. Each user's visited days are tracked
+// as a bitmap (one bit per day index), which bounds the supported range to
+// 64 days.
+// Time Complexity: O(n), where n is the total number of log entries
+// Space Complexity: O(m), where m is the number of unique users
+func getLoyalCustomers(ctx context.Context, days [][]processor.LogInfo, minDays, minPages int) ([]int64, error) {
+	if len(days) > 64 {
+		return nil, fmt.Errorf("getLoyalCustomers: %d days exceeds the 64-day bitmap limit", len(days))
 	}
 
-	loyalCustomersMap := make(map[int64]bool)
+	userStats := make(map[int64]struct {
+		pages   map[string]bool
+		visited uint64
+	})
 
-	for userID, info := range userPages {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			if info.visitDays == 2 && len(info.pages) >= minLoyalPages {
-				loyalCustomersMap[userID] = true
+	for dayIdx, logs := range days {
+		for _, l := range logs {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			stats, ok := userStats[l.UserID]
+			if !ok {
+				stats.pages = make(map[string]bool)
 			}
+
+			stats.pages[l.PageName] = true
+			stats.visited |= 1 << uint(dayIdx)
+			userStats[l.UserID] = stats
 		}
 	}
 
-	loyalCustomers := make([]int64, 0, len(loyalCustomersMap))
-	for userID := range loyalCustomersMap {
-		loyalCustomers = append(loyalCustomers, userID)
+	loyalCustomers := make([]int64, 0)
+
+	for userID, stats := range userStats {
+		if bits.OnesCount64(stats.visited) >= minDays && len(stats.pages) >= minPages {
+			loyalCustomers = append(loyalCustomers, userID)
+		}
 	}
 
 	sort.Slice(loyalCustomers, func(i, j int) bool {
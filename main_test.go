@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/ashtishad/log-analyzer/processor"
 	"github.com/ashtishad/log-analyzer/seed"
 )
 
@@ -14,7 +16,7 @@ func TestGetLoyalCustomers(t *testing.T) {
 	day1 := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
 	day2 := day1.AddDate(0, 0, 1)
 
-	file1 := []proccessor.LogInfo{
+	file1 := []processor.LogInfo{
 		{UserID: 1, PageName: "blog", Timestamp: day1},
 		{UserID: 1, PageName: "dashboard", Timestamp: day1},
 		{UserID: 1, PageName: "shop", Timestamp: day1},
@@ -29,7 +31,7 @@ func TestGetLoyalCustomers(t *testing.T) {
 		{UserID: 7, PageName: "blog", Timestamp: day1},
 	}
 
-	file2 := []proccessor.LogInfo{
+	file2 := []processor.LogInfo{
 		{UserID: 1, PageName: "blog", Timestamp: day2},
 		{UserID: 1, PageName: "about", Timestamp: day2},
 		{UserID: 2, PageName: "blog", Timestamp: day2},
@@ -43,7 +45,7 @@ func TestGetLoyalCustomers(t *testing.T) {
 	}
 
 	expected := []int64{1, 3, 6}
-	result, err := getLoyalCustomers(context.Background(), file1, file2)
+	result, err := getLoyalCustomers(context.Background(), [][]processor.LogInfo{file1, file2}, minLoyalDays, minLoyalPages)
 
 	if err != nil {
 		t.Errorf("getLoyalCustomers() returned an error: %v", err)
@@ -59,7 +61,7 @@ func FuzzGetLoyalCustomers(f *testing.F) {
 		[]byte(`[{"userId":1,"pageName":"shop","timestamp":"2024-10-02T00:00:00Z"}]`))
 
 	f.Fuzz(func(t *testing.T, data1, data2 []byte) {
-		var file1, file2 []proccessor.LogInfo
+		var file1, file2 []processor.LogInfo
 
 		if err := json.Unmarshal(data1, &file1); err != nil {
 			return // invalid input, skip this iteration
@@ -71,7 +73,9 @@ func FuzzGetLoyalCustomers(f *testing.F) {
 		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 		defer cancel()
 
-		result, err := getLoyalCustomers(ctx, file1, file2)
+		days := [][]processor.LogInfo{file1, file2}
+
+		result, err := getLoyalCustomers(ctx, days, minLoyalDays, minLoyalPages)
 
 		if err != nil && err != context.DeadlineExceeded {
 			t.Errorf("getLoyalCustomers() returned an unexpected error: %v", err)
@@ -119,7 +123,12 @@ func BenchmarkGetLoyalCustomers(b *testing.B) {
 	}
 
 	ctx := context.Background()
-	file1, file2, err := processLogs(ctx)
+
+	pattern := filepath.Join("seed", "files", "logs_%Y-%m-%d.log")
+	from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	days, err := processLogs(ctx, pattern, from, to, false)
 	if err != nil {
 		b.Fatalf("Failed to process logs: %v", err)
 	}
@@ -127,7 +136,7 @@ func BenchmarkGetLoyalCustomers(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_, err := getLoyalCustomers(ctx, file1, file2)
+		_, err := getLoyalCustomers(ctx, days, minLoyalDays, minLoyalPages)
 		if err != nil {
 			b.Fatalf("getLoyalCustomers() returned an error: %v", err)
 		}
@@ -0,0 +1,208 @@
+package processor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkpointFlushInterval throttles how often accumulated chunk progress is
+// persisted to the CheckpointStore.
+const checkpointFlushInterval = 250 * time.Millisecond
+
+// checkpointProgressLines and checkpointProgressPeriod bound how often a
+// single worker reports its progress to the checkpoint writer.
+const (
+	checkpointProgressLines  = 500
+	checkpointProgressPeriod = 250 * time.Millisecond
+)
+
+// Checkpoint records enough about a file and its chunk split to tell
+// whether a prior checkpoint still applies, plus where each chunk's worker
+// had reached.
+type Checkpoint struct {
+	FileSize       int64     `json:"fileSize"`
+	ModTime        time.Time `json:"modTime"`
+	PerChunkOffset []int64   `json:"perChunkOffset"`
+}
+
+// CheckpointStore persists per-file chunk progress so ReadLogFile can resume
+// mid-file after a crash or a graceful shutdown instead of reparsing from
+// scratch.
+type CheckpointStore interface {
+	// Load returns the checkpoint for filePath, or ok=false if none exists.
+	Load(filePath string) (cp Checkpoint, ok bool, err error)
+	// Save persists checkpoint for filePath, overwriting any prior one.
+	Save(filePath string, cp Checkpoint) error
+	// Delete removes filePath's checkpoint, if any. Called once a file has
+	// been fully read, since there's nothing left to resume.
+	Delete(filePath string) error
+}
+
+// WithCheckpoint makes ReadLogFile resume from, and periodically persist
+// progress to, store.
+func WithCheckpoint(store CheckpointStore) ReadOption {
+	return func(c *readConfig) {
+		c.checkpoint = store
+	}
+}
+
+// fileCheckpointStore is the default CheckpointStore: it writes a small JSON
+// sidecar file next to the log, e.g. "logs_2024-10-01.log.ckpt".
+type fileCheckpointStore struct{}
+
+// NewFileCheckpointStore returns a CheckpointStore backed by a JSON sidecar
+// file written next to each log file, using an atomic write-then-rename so
+// a crash mid-write can't leave a corrupt checkpoint behind.
+func NewFileCheckpointStore() CheckpointStore {
+	return fileCheckpointStore{}
+}
+
+func checkpointPath(filePath string) string {
+	return filePath + ".ckpt"
+}
+
+func (fileCheckpointStore) Load(filePath string) (Checkpoint, bool, error) {
+	data, err := os.ReadFile(checkpointPath(filePath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Checkpoint{}, false, nil
+		}
+
+		return Checkpoint{}, false, err
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return Checkpoint{}, false, err
+	}
+
+	return cp, true, nil
+}
+
+func (fileCheckpointStore) Save(filePath string, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := checkpointPath(filePath) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, checkpointPath(filePath))
+}
+
+func (fileCheckpointStore) Delete(filePath string) error {
+	err := os.Remove(checkpointPath(filePath))
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// checkpointWriter aggregates per-chunk progress reports from ReadLogFile's
+// workers and periodically persists a snapshot to a CheckpointStore.
+type checkpointWriter struct {
+	store    CheckpointStore
+	filePath string
+
+	mu        sync.Mutex
+	cp        Checkpoint
+	lastFlush time.Time
+}
+
+// newCheckpointWriter loads any existing checkpoint for filePath, validates
+// it against the file's current size/mtime and chunk count, and returns a
+// writer seeded with the resumable offsets (or each part's own starting
+// offset, if no valid checkpoint exists).
+func newCheckpointWriter(filePath string, store CheckpointStore, parts []filePart) (*checkpointWriter, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cw := &checkpointWriter{
+		store:    store,
+		filePath: filePath,
+		cp: Checkpoint{
+			FileSize:       info.Size(),
+			ModTime:        info.ModTime(),
+			PerChunkOffset: make([]int64, len(parts)),
+		},
+		lastFlush: time.Now(),
+	}
+
+	for i, p := range parts {
+		cw.cp.PerChunkOffset[i] = p.offset
+	}
+
+	prior, ok, err := store.Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && prior.FileSize == cw.cp.FileSize && prior.ModTime.Equal(cw.cp.ModTime) && len(prior.PerChunkOffset) == len(parts) {
+		cw.cp.PerChunkOffset = prior.PerChunkOffset
+	}
+
+	return cw, nil
+}
+
+// applyResumeOffsets adjusts parts in place so each chunk starts from its
+// checkpointed offset instead of its naive boundary.
+func (cw *checkpointWriter) applyResumeOffsets(parts []filePart) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	for i := range parts {
+		resume := cw.cp.PerChunkOffset[i]
+		if resume <= parts[i].offset {
+			continue
+		}
+
+		end := parts[i].offset + parts[i].size
+		if resume > end {
+			resume = end
+		}
+
+		parts[i].size = end - resume
+		parts[i].offset = resume
+	}
+}
+
+// update records chunk i's latest absolute offset and flushes a snapshot to
+// the store if enough time has passed since the last flush.
+func (cw *checkpointWriter) update(chunk int, offset int64) {
+	cw.mu.Lock()
+
+	cw.cp.PerChunkOffset[chunk] = offset
+
+	shouldFlush := time.Since(cw.lastFlush) >= checkpointFlushInterval
+
+	var snapshot Checkpoint
+	if shouldFlush {
+		snapshot = Checkpoint{
+			FileSize:       cw.cp.FileSize,
+			ModTime:        cw.cp.ModTime,
+			PerChunkOffset: append([]int64(nil), cw.cp.PerChunkOffset...),
+		}
+		cw.lastFlush = time.Now()
+	}
+
+	cw.mu.Unlock()
+
+	if shouldFlush {
+		_ = cw.store.Save(cw.filePath, snapshot)
+	}
+}
+
+// finish removes the checkpoint, since a fully-read file has nothing left
+// to resume.
+func (cw *checkpointWriter) finish() {
+	_ = cw.store.Delete(cw.filePath)
+}
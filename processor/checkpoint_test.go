@@ -0,0 +1,204 @@
+package processor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestProcessFilePartResumeOffsetNoSkip simulates a crash partway through a
+// chunk: it decodes the first few records the same way processFilePart does,
+// noting the offset trackingSplitFunc reports after them, then resumes from
+// that exact offset. Before trackingSplitFunc replaced the raw
+// countingReader-based offset, the reported offset ran far ahead of the
+// records actually decoded (bufio.Scanner reads well beyond the token it
+// just returned), so resuming from it silently dropped everything in
+// between. This asserts the records from both halves, concatenated, exactly
+// match the full file, in order, with none skipped or repeated.
+func TestProcessFilePartResumeOffsetNoSkip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.log")
+
+	const total = 20
+
+	var data []byte
+	for i := 0; i < total; i++ {
+		data = append(data, []byte(fmt.Sprintf(`{"userId":%d,"pageName":"home","timestamp":"2024-10-01T00:00:00Z"}`+"\n", i))...)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	codec := DefaultCodec
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	var consumed int64
+
+	scanner := bufio.NewScanner(file)
+	scanner.Split(trackingSplitFunc(codec.SplitFunc(), &consumed))
+
+	const crashAfter = 7
+
+	var firstHalf []LogInfo
+
+	for i := 0; i < crashAfter && scanner.Scan(); i++ {
+		log, err := codec.Decode(scanner.Bytes())
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+
+		firstHalf = append(firstHalf, log)
+	}
+
+	file.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+
+	// Resume exactly where the "crash" left off - consumed now reflects only
+	// bytes belonging to the 7 records already decoded above.
+	secondHalf, err := processFilePart(path, consumed, info.Size()-consumed, codec, nil)
+	if err != nil {
+		t.Fatalf("processFilePart (resume): %v", err)
+	}
+
+	var gotIDs []int64
+	for _, l := range firstHalf {
+		gotIDs = append(gotIDs, l.UserID)
+	}
+
+	for _, l := range secondHalf {
+		gotIDs = append(gotIDs, l.UserID)
+	}
+
+	if len(gotIDs) != total {
+		t.Fatalf("got %d records across both halves, want %d (resume skipped or duplicated records): %v", len(gotIDs), total, gotIDs)
+	}
+
+	for i, id := range gotIDs {
+		if id != int64(i) {
+			t.Fatalf("got %v, want records 0..%d in order (resume skipped or duplicated records)", gotIDs, total-1)
+		}
+	}
+}
+
+// fakeCheckpointStore is an in-memory CheckpointStore for tests.
+type fakeCheckpointStore struct {
+	checkpoints map[string]Checkpoint
+}
+
+func newFakeCheckpointStore() *fakeCheckpointStore {
+	return &fakeCheckpointStore{checkpoints: make(map[string]Checkpoint)}
+}
+
+func (f *fakeCheckpointStore) Load(filePath string) (Checkpoint, bool, error) {
+	cp, ok := f.checkpoints[filePath]
+	return cp, ok, nil
+}
+
+func (f *fakeCheckpointStore) Save(filePath string, cp Checkpoint) error {
+	f.checkpoints[filePath] = cp
+	return nil
+}
+
+func (f *fakeCheckpointStore) Delete(filePath string) error {
+	delete(f.checkpoints, filePath)
+	return nil
+}
+
+// TestReadLogFileResumesFromCheckpointWithoutSkipping plants a checkpoint
+// that resumes chunk 0 partway through (rather than at its naive boundary)
+// and checks ReadLogFile still returns every record the chunk actually
+// contains, with none skipped ahead of where they were really decoded.
+func TestReadLogFileResumesFromCheckpointWithoutSkipping(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.log")
+
+	const total = 400
+
+	var data []byte
+	for i := 0; i < total; i++ {
+		data = append(data, []byte(fmt.Sprintf(`{"userId":%d,"pageName":"home","timestamp":"2024-10-01T00:00:00Z"}`+"\n", i))...)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	parts, err := splitFile(path, NumOfChunks, DefaultCodec)
+	if err != nil {
+		t.Fatalf("splitFile: %v", err)
+	}
+
+	// Pretend chunk 0 had already advanced a little way into its range
+	// before the prior run crashed.
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	defer file.Close()
+
+	if _, err := file.Seek(parts[0].offset, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	var consumed int64
+
+	scanner := bufio.NewScanner(file)
+	scanner.Split(trackingSplitFunc(DefaultCodec.SplitFunc(), &consumed))
+
+	const crashAfter = 3
+
+	for i := 0; i < crashAfter && scanner.Scan(); i++ {
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+
+	perChunkOffset := make([]int64, len(parts))
+	for i, p := range parts {
+		perChunkOffset[i] = p.offset
+	}
+
+	perChunkOffset[0] = parts[0].offset + consumed
+
+	store := newFakeCheckpointStore()
+	store.checkpoints[path] = Checkpoint{
+		FileSize:       info.Size(),
+		ModTime:        info.ModTime(),
+		PerChunkOffset: perChunkOffset,
+	}
+
+	logs, err := ReadLogFile(context.Background(), path, WithCheckpoint(store))
+	if err != nil {
+		t.Fatalf("ReadLogFile: %v", err)
+	}
+
+	seen := make(map[int64]bool)
+	for _, l := range logs {
+		if seen[l.UserID] {
+			t.Fatalf("record %d decoded twice after resume", l.UserID)
+		}
+
+		seen[l.UserID] = true
+	}
+
+	for i := crashAfter; i < total; i++ {
+		if !seen[int64(i)] {
+			t.Fatalf("record %d missing after resume: resume skipped records it never decoded", i)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package processor
+
+import "bufio"
+
+// LogCodec abstracts how a log file's raw bytes are split into discrete
+// records and how each record is decoded into a LogInfo. This lets
+// ReadLogFile (and the chunk-boundary search in splitFile) work the same way
+// regardless of wire format.
+type LogCodec interface {
+	// Decode parses a single complete record, as delimited by SplitFunc,
+	// into a LogInfo.
+	Decode(record []byte) (LogInfo, error)
+	// SplitFunc returns a bufio.SplitFunc that finds complete record
+	// boundaries within a stream of bytes.
+	SplitFunc() bufio.SplitFunc
+}
+
+// JSONCodec decodes newline-delimited JSON log lines.
+var JSONCodec LogCodec = jsonCodec{}
+
+// DefaultCodec is used by ReadLogFile and ReadLogTree when no other codec is
+// configured.
+var DefaultCodec = JSONCodec
+
+// jsonCodec implements LogCodec over the newline-delimited JSON format
+// produced by seed.GenerateLogFiles.
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(record []byte) (LogInfo, error) {
+	return parseLogLine(record)
+}
+
+func (jsonCodec) SplitFunc() bufio.SplitFunc {
+	return bufio.ScanLines
+}
@@ -0,0 +1,150 @@
+package processor
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ProtobufCodec decodes a length-prefixed stream of LogEntry protobuf
+// messages: each record is a varint byte-length followed by that many bytes
+// of protobuf wire format, with fields user_id (1, varint), page_name (2,
+// length-delimited) and timestamp_unix_nanos (3, varint). It's a minimal,
+// dependency-free encoder/decoder for that one message shape, in keeping
+// with parseLogLine's byte-level approach to avoiding generic parsing
+// overhead.
+var ProtobufCodec LogCodec = protobufCodec{}
+
+const (
+	protoFieldUserID    = 1
+	protoFieldPageName  = 2
+	protoFieldTimestamp = 3
+
+	protoWireVarint = 0
+	protoWireBytes  = 2
+)
+
+type protobufCodec struct{}
+
+func (protobufCodec) SplitFunc() bufio.SplitFunc {
+	return splitProtobufRecord
+}
+
+// splitProtobufRecord is a bufio.SplitFunc that reads a varint length prefix
+// and returns the following recLen bytes as a token, so scanner.Bytes()
+// yields the raw LogEntry payload without its length prefix.
+func splitProtobufRecord(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	recLen, n := binary.Uvarint(data)
+	if n == 0 {
+		if atEOF {
+			return 0, nil, fmt.Errorf("protobuf codec: truncated length prefix")
+		}
+
+		return 0, nil, nil
+	}
+
+	if n < 0 {
+		return 0, nil, fmt.Errorf("protobuf codec: length prefix overflows a uint64")
+	}
+
+	total := n + int(recLen)
+	if len(data) < total {
+		if atEOF {
+			return 0, nil, fmt.Errorf("protobuf codec: truncated record")
+		}
+
+		return 0, nil, nil
+	}
+
+	return total, data[n:total], nil
+}
+
+// Decode parses a single LogEntry protobuf payload (without its length
+// prefix) into a LogInfo.
+func (protobufCodec) Decode(record []byte) (LogInfo, error) {
+	var log LogInfo
+
+	buf := record
+
+	for len(buf) > 0 {
+		tag, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return log, fmt.Errorf("protobuf codec: invalid field tag")
+		}
+
+		buf = buf[n:]
+
+		fieldNum := tag >> 3
+		wireType := tag & 0x7
+
+		switch wireType {
+		case protoWireVarint:
+			v, n := binary.Uvarint(buf)
+			if n <= 0 {
+				return log, fmt.Errorf("protobuf codec: invalid varint for field %d", fieldNum)
+			}
+
+			buf = buf[n:]
+
+			switch fieldNum {
+			case protoFieldUserID:
+				log.UserID = int64(v)
+			case protoFieldTimestamp:
+				log.Timestamp = time.Unix(0, int64(v)).UTC()
+			}
+		case protoWireBytes:
+			l, n := binary.Uvarint(buf)
+			if n <= 0 {
+				return log, fmt.Errorf("protobuf codec: invalid length for field %d", fieldNum)
+			}
+
+			buf = buf[n:]
+
+			if uint64(len(buf)) < l {
+				return log, fmt.Errorf("protobuf codec: truncated field %d", fieldNum)
+			}
+
+			if fieldNum == protoFieldPageName {
+				log.PageName = string(buf[:l])
+			}
+
+			buf = buf[l:]
+		default:
+			return log, fmt.Errorf("protobuf codec: unsupported wire type %d for field %d", wireType, fieldNum)
+		}
+	}
+
+	return log, nil
+}
+
+// EncodeLogEntry encodes a single log entry in the wire format ProtobufCodec
+// decodes, framed with a leading varint byte-length so records can be
+// concatenated directly into a file.
+func EncodeLogEntry(userID int64, pageName string, timestamp time.Time) []byte {
+	var msg []byte
+
+	msg = appendTag(msg, protoFieldUserID, protoWireVarint)
+	msg = binary.AppendUvarint(msg, uint64(userID))
+
+	msg = appendTag(msg, protoFieldPageName, protoWireBytes)
+	msg = binary.AppendUvarint(msg, uint64(len(pageName)))
+	msg = append(msg, pageName...)
+
+	msg = appendTag(msg, protoFieldTimestamp, protoWireVarint)
+	msg = binary.AppendUvarint(msg, uint64(timestamp.UnixNano()))
+
+	record := binary.AppendUvarint(nil, uint64(len(msg)))
+	record = append(record, msg...)
+
+	return record
+}
+
+// appendTag appends a protobuf field tag (field number and wire type) to buf.
+func appendTag(buf []byte, fieldNum, wireType uint64) []byte {
+	return binary.AppendUvarint(buf, fieldNum<<3|wireType)
+}
@@ -0,0 +1,127 @@
+package processor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProtobufCodecRoundTrip(t *testing.T) {
+	want := LogInfo{
+		UserID:    42,
+		PageName:  "dashboard",
+		Timestamp: time.Date(2024, 10, 1, 15, 30, 0, 0, time.UTC),
+	}
+
+	record := EncodeLogEntry(want.UserID, want.PageName, want.Timestamp)
+
+	advance, token, err := splitProtobufRecord(record, true)
+	if err != nil {
+		t.Fatalf("splitProtobufRecord() error: %v", err)
+	}
+
+	if advance != len(record) {
+		t.Fatalf("splitProtobufRecord() advance = %d, want %d", advance, len(record))
+	}
+
+	got, err := ProtobufCodec.Decode(token)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+
+	if got.UserID != want.UserID || got.PageName != want.PageName || !got.Timestamp.Equal(want.Timestamp) {
+		t.Fatalf("Decode() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSplitProtobufRecordIncomplete(t *testing.T) {
+	record := EncodeLogEntry(1, "home", time.Unix(0, 0))
+
+	// A truncated record with more bytes possibly still to come must ask
+	// for more data rather than erroring, unless we're at EOF.
+	advance, token, err := splitProtobufRecord(record[:len(record)-1], false)
+	if err != nil {
+		t.Fatalf("splitProtobufRecord() error on truncated, non-EOF input: %v", err)
+	}
+
+	if advance != 0 || token != nil {
+		t.Fatalf("splitProtobufRecord() = (%d, %v), want (0, nil) for incomplete input", advance, token)
+	}
+
+	if _, _, err := splitProtobufRecord(record[:len(record)-1], true); err == nil {
+		t.Fatal("splitProtobufRecord() at EOF with a truncated record should error")
+	}
+}
+
+// TestSplitFileProtobufBoundariesLandOnRealRecords drives a multi-chunk
+// protobuf file through the same splitFile/findRecordBoundary path
+// ReadLogFile and ReadLogTree use. Unlike newline-delimited JSON, a
+// protobuf record has no unambiguous delimiter, so a chunk's naive
+// byte-offset boundary can easily land mid-record; findRecordBoundary has
+// to resync to a genuine record start rather than trusting the first
+// plausible-looking one.
+func TestSplitFileProtobufBoundariesLandOnRealRecords(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs.pb")
+
+	const total = 2000
+
+	var data []byte
+
+	for i := 0; i < total; i++ {
+		data = append(data, EncodeLogEntry(int64(i), "dashboard", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))...)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	parts, err := splitFile(path, NumOfChunks, ProtobufCodec)
+	if err != nil {
+		t.Fatalf("splitFile: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	defer file.Close()
+
+	for i, p := range parts {
+		if i == 0 {
+			continue // chunk 0 always starts at byte 0, trivially a real boundary
+		}
+
+		ok, err := verifyResync(file, p.offset, ProtobufCodec)
+		if err != nil {
+			t.Fatalf("verifyResync(chunk %d): %v", i, err)
+		}
+
+		if !ok {
+			t.Fatalf("chunk %d's boundary at offset %d does not land on a real record start", i, p.offset)
+		}
+	}
+
+	logs, err := ReadLogFile(context.Background(), path, WithCodec(ProtobufCodec))
+	if err != nil {
+		t.Fatalf("ReadLogFile: %v", err)
+	}
+
+	seen := make(map[int64]bool)
+	for _, l := range logs {
+		if seen[l.UserID] {
+			t.Fatalf("record %d decoded twice", l.UserID)
+		}
+
+		seen[l.UserID] = true
+	}
+
+	for i := 0; i < total; i++ {
+		if !seen[int64(i)] {
+			t.Fatalf("record %d missing from ReadLogFile() output", i)
+		}
+	}
+}
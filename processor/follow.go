@@ -0,0 +1,240 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	followDefaultPollInterval = 200 * time.Millisecond
+	followReadChunk           = 64 * 1024
+)
+
+// followConfig holds the options Follow is configured with.
+type followConfig struct {
+	codec        LogCodec
+	fromStart    bool
+	pollInterval time.Duration
+}
+
+// FollowOption configures Follow.
+type FollowOption func(*followConfig)
+
+// WithFollowCodec selects the LogCodec used to split and decode appended
+// bytes. Defaults to DefaultCodec when not supplied.
+func WithFollowCodec(codec LogCodec) FollowOption {
+	return func(c *followConfig) {
+		c.codec = codec
+	}
+}
+
+// WithFollowFromStart makes Follow read the current file from its beginning
+// instead of seeking to EOF on first open. Defaults to false (tail from
+// EOF).
+func WithFollowFromStart(fromStart bool) FollowOption {
+	return func(c *followConfig) {
+		c.fromStart = fromStart
+	}
+}
+
+// WithFollowPollInterval sets how long Follow sleeps between reads when no
+// new data is available, and how often it re-checks the pattern and the
+// current file's identity for rotation. Defaults to followDefaultPollInterval.
+func WithFollowPollInterval(d time.Duration) FollowOption {
+	return func(c *followConfig) {
+		c.pollInterval = d
+	}
+}
+
+// Follow tails the actively-written log file identified by pattern (a
+// strftime-style template, e.g. "logs_%Y-%m-%d.log"), emitting parsed
+// entries on out as they're appended. It transparently rolls over to the
+// next file when the wall clock ticks into a new interval (the pattern
+// re-resolves to a different path) or the file currently open has been
+// replaced in place, detected with os.SameFile so the same code handles
+// inode changes on Unix and file-identity changes on Windows. Follow blocks
+// with a small poll-interval backoff on short reads and runs until ctx is
+// cancelled or an unrecoverable error occurs.
+func Follow(ctx context.Context, pattern string, out chan<- LogInfo, opts ...FollowOption) error {
+	cfg := &followConfig{codec: DefaultCodec, pollInterval: followDefaultPollInterval}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	fl := &follower{pattern: pattern, codec: cfg.codec, out: out, readBuf: make([]byte, followReadChunk)}
+	defer fl.close()
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := fl.ensureOpen(cfg.fromStart); err != nil {
+			return err
+		}
+
+		if fl.file != nil {
+			if err := fl.drain(ctx); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cfg.pollInterval):
+		}
+
+		if fl.file != nil {
+			rotated, err := fl.checkRotation()
+			if err != nil {
+				return err
+			}
+
+			if rotated {
+				fl.close()
+			}
+		}
+	}
+}
+
+// follower holds the state Follow needs across poll iterations: the
+// currently-open file, its identity (for rotation detection), and any
+// partially-read trailing bytes that don't yet form a complete record.
+type follower struct {
+	pattern string
+	codec   LogCodec
+	out     chan<- LogInfo
+
+	readBuf []byte
+	pending []byte
+
+	file       *os.File
+	fileInfo   os.FileInfo
+	curPath    string
+	everOpened bool
+}
+
+// ensureOpen opens the file the pattern currently resolves to, if one isn't
+// already open. A file that doesn't exist yet is treated as "not written
+// yet" rather than an error: Follow will retry on the next poll. fromStart
+// only applies to the very first file Follow ever opens; any file picked up
+// afterward - via rotation to a new path, or the current path's file being
+// replaced - is always read from byte 0, since it may already contain
+// entries written before the rotation was noticed.
+func (f *follower) ensureOpen(fromStart bool) error {
+	if f.file != nil {
+		return nil
+	}
+
+	resolved := ExpandPattern(f.pattern, time.Now())
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if !fromStart && !f.everOpened {
+		if _, err := file.Seek(0, io.SeekEnd); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	f.file = file
+	f.fileInfo = info
+	f.curPath = resolved
+	f.pending = f.pending[:0]
+	f.everOpened = true
+
+	return nil
+}
+
+// checkRotation reports whether the currently-open file should be closed
+// and re-resolved: either the pattern now points at a different path (a new
+// interval started), or the path is the same but the underlying file has
+// been replaced.
+func (f *follower) checkRotation() (bool, error) {
+	resolved := ExpandPattern(f.pattern, time.Now())
+	if resolved != f.curPath {
+		return true, nil
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !os.SameFile(f.fileInfo, info), nil
+}
+
+// drain reads every byte appended since the last call and emits each
+// complete record it can decode, leaving any trailing partial record in
+// f.pending for the next call.
+func (f *follower) drain(ctx context.Context) error {
+	split := f.codec.SplitFunc()
+
+	for {
+		n, readErr := f.file.Read(f.readBuf)
+		if n > 0 {
+			f.pending = append(f.pending, f.readBuf[:n]...)
+
+			for {
+				advance, token, splitErr := split(f.pending, false)
+				if splitErr != nil {
+					return splitErr
+				}
+
+				if advance == 0 {
+					break
+				}
+
+				if token != nil {
+					if log, decodeErr := f.codec.Decode(token); decodeErr == nil {
+						select {
+						case f.out <- log:
+						case <-ctx.Done():
+							return ctx.Err()
+						}
+					}
+				}
+
+				f.pending = f.pending[advance:]
+			}
+		}
+
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				return nil
+			}
+
+			return readErr
+		}
+	}
+}
+
+// close releases the currently-open file, if any.
+func (f *follower) close() {
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+		f.fileInfo = nil
+	}
+}
@@ -0,0 +1,30 @@
+package processor
+
+import (
+	"strings"
+	"time"
+)
+
+// dateTokens maps strftime-style tokens to Go reference-time layout
+// fragments. Tokens not listed here are left untouched by ExpandPattern.
+var dateTokens = []struct {
+	token  string
+	layout string
+}{
+	{"%Y", "2006"},
+	{"%m", "01"},
+	{"%d", "02"},
+	{"%H", "15"},
+}
+
+// ExpandPattern substitutes strftime-style date tokens in pattern with the
+// corresponding fields of date. Tokens it doesn't recognise are left as-is.
+func ExpandPattern(pattern string, date time.Time) string {
+	expanded := pattern
+
+	for _, tok := range dateTokens {
+		expanded = strings.ReplaceAll(expanded, tok.token, date.Format(tok.layout))
+	}
+
+	return expanded
+}
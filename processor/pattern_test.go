@@ -0,0 +1,28 @@
+package processor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandPattern(t *testing.T) {
+	date := time.Date(2024, 10, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{"all tokens", "logs_%Y-%m-%d_%H.log", "logs_2024-10-01_15.log"},
+		{"no tokens", "logs/static.log", "logs/static.log"},
+		{"unknown token left as-is", "logs_%Y_%Q.log", "logs_2024_%Q.log"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandPattern(tt.pattern, date); got != tt.want {
+				t.Errorf("ExpandPattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,10 @@ import (
 
 const NumOfChunks = 4 // NumOfChunks = runtime.NumCPU()
 
+// recordBoundaryProbeSize is how many bytes splitFile reads forward from a
+// rough chunk boundary while looking for the start of the next full record.
+const recordBoundaryProbeSize = 4096
+
 // LogInfo represents a single log entry with parsed user activity data.
 type LogInfo struct {
 	UserID    int64
@@ -27,9 +31,31 @@ type filePart struct {
 	size   int64
 }
 
-// splitFile divides the file for concurrent processing while preserving log integrity.
+// readConfig holds the options ReadLogFile is configured with.
+type readConfig struct {
+	codec      LogCodec
+	checkpoint CheckpointStore
+}
+
+// ReadOption configures ReadLogFile.
+type ReadOption func(*readConfig)
+
+// WithCodec selects the LogCodec used to split and decode the file. Defaults
+// to DefaultCodec (newline-delimited JSON) when not supplied.
+func WithCodec(codec LogCodec) ReadOption {
+	return func(c *readConfig) {
+		c.codec = codec
+	}
+}
+
+// splitFile divides the file for concurrent processing while preserving
+// record integrity, using codec's SplitFunc to find each chunk boundary.
 // Uses direct file I/O operations for performance, avoiding full file read into memory.
-func splitFile(filePath string, numParts int) ([]filePart, error) {
+// Each chunk's offset is chained from the previous chunk's adjusted end
+// rather than recomputed as i*partSize independently: once a chunk's size is
+// extended to land on a real record boundary, the next chunk must start
+// exactly there, or the extended span would be decoded by both chunks.
+func splitFile(filePath string, numParts int, codec LogCodec) ([]filePart, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
@@ -47,59 +73,167 @@ func splitFile(filePath string, numParts int) ([]filePart, error) {
 
 	var parts []filePart
 
+	var cursor int64
+
 	for i := 0; i < numParts; i++ {
-		offset := int64(i) * partSize
-		size := partSize
+		offset := cursor
+		size := fileSize - offset
 
-		if i == numParts-1 {
-			size = fileSize - offset
-		} else {
-			_, err := file.Seek(offset+size, io.SeekStart)
+		if i != numParts-1 {
+			adjust, err := findRecordBoundary(file, offset+partSize, codec)
 			if err != nil {
 				return nil, err
 			}
 
-			buf := make([]byte, 100)
-			n, err := file.Read(buf)
-
-			if err != nil && err != io.EOF {
-				return nil, err
-			}
-
-			for j := 0; j < n; j++ {
-				if buf[j] == '\n' {
-					size += int64(j) + 1
-					break
-				}
-			}
+			size = partSize + adjust
 		}
 
 		parts = append(parts, filePart{offset: offset, size: size})
+		cursor = offset + size
 	}
 
 	return parts, nil
 }
 
+// requiredResyncRecords is how many consecutive records must parse cleanly
+// past a candidate record boundary before findRecordBoundary trusts it.
+// Self-delimiting formats like newline-terminated JSON satisfy this on the
+// very first real candidate; dense binary formats like ProtobufCodec need it
+// to rule out a byte sequence that only looks like a valid record by luck.
+const requiredResyncRecords = 3
+
+// findRecordBoundary scans forward from roughOffset one byte at a time,
+// using codec's SplitFunc and Decode to test each position as a candidate
+// record start, and returns how many bytes to add to a naively-sized chunk
+// so it ends exactly on a real record boundary. A candidate only counts once
+// verifyResync confirms several more records parse cleanly after it: for a
+// self-delimiting format that's true of the very first candidate, but for a
+// format like ProtobufCodec a rough offset landing mid-record can easily
+// parse as a plausible-looking, entirely wrong "record" with no other
+// signal to reject it. If no verified boundary is found within
+// recordBoundaryProbeSize bytes, it gives up and returns 0, leaving the
+// chunk at its naive size.
+func findRecordBoundary(file *os.File, roughOffset int64, codec LogCodec) (int64, error) {
+	buf := make([]byte, recordBoundaryProbeSize)
+
+	n, err := file.ReadAt(buf, roughOffset)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	buf = buf[:n]
+	atEOF := err == io.EOF
+
+	split := codec.SplitFunc()
+
+	for pos := 0; pos < len(buf); pos++ {
+		advance, token, splitErr := split(buf[pos:], atEOF)
+		if splitErr != nil || advance == 0 || token == nil {
+			continue
+		}
+
+		if _, decodeErr := codec.Decode(token); decodeErr != nil {
+			continue
+		}
+
+		candidate := int64(pos + advance)
+
+		ok, err := verifyResync(file, roughOffset+candidate, codec)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			return candidate, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// verifyResync reports whether requiredResyncRecords consecutive records,
+// starting at absolute file offset at, all parse via codec's SplitFunc and
+// Decode without error.
+func verifyResync(file *os.File, at int64, codec LogCodec) (bool, error) {
+	buf := make([]byte, recordBoundaryProbeSize)
+
+	n, err := file.ReadAt(buf, at)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	buf = buf[:n]
+	atEOF := err == io.EOF
+
+	split := codec.SplitFunc()
+
+	var consumed int
+
+	for i := 0; i < requiredResyncRecords; i++ {
+		advance, token, splitErr := split(buf[consumed:], atEOF)
+		if splitErr != nil {
+			return false, nil
+		}
+
+		if advance == 0 {
+			// Not enough probe data left to confirm another record. If at
+			// least one record already verified and we've hit EOF, there's
+			// nothing left to check against - that's a pass, not a failure.
+			return atEOF && i > 0, nil
+		}
+
+		if _, decodeErr := codec.Decode(token); decodeErr != nil {
+			return false, nil
+		}
+
+		consumed += advance
+	}
+
+	return true, nil
+}
+
 // ReadLogFile implements concurrent log processing for improved performance on multi-core systems.
-// Uses goroutines and channels for parallel execution and result aggregation.
-func ReadLogFile(ctx context.Context, filename string) ([]LogInfo, error) {
-	parts, err := splitFile(filename, NumOfChunks)
+// Uses goroutines and channels for parallel execution and result aggregation. When configured with
+// WithCheckpoint, each chunk resumes from its last reported offset instead of reprocessing the whole file.
+func ReadLogFile(ctx context.Context, filename string, opts ...ReadOption) ([]LogInfo, error) {
+	cfg := &readConfig{codec: DefaultCodec}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	parts, err := splitFile(filename, NumOfChunks, cfg.codec)
 	if err != nil {
 		return nil, err
 	}
 
+	var cw *checkpointWriter
+
+	if cfg.checkpoint != nil {
+		cw, err = newCheckpointWriter(filename, cfg.checkpoint, parts)
+		if err != nil {
+			return nil, err
+		}
+
+		cw.applyResumeOffsets(parts)
+	}
+
 	var wg sync.WaitGroup
 
 	resultsCh := make(chan []LogInfo, NumOfChunks)
 	errCh := make(chan error, 1)
 
-	for _, part := range parts {
+	for i, part := range parts {
 		wg.Add(1)
 
-		go func(p filePart) {
+		go func(i int, p filePart) {
 			defer wg.Done()
 
-			logs, err := processFilePart(filename, p.offset, p.size)
+			var report func(int64)
+			if cw != nil {
+				report = func(absOffset int64) { cw.update(i, absOffset) }
+			}
+
+			logs, err := processFilePart(filename, p.offset, p.size, cfg.codec, report)
 			if err != nil {
 				select {
 				case errCh <- err:
@@ -109,7 +243,7 @@ func ReadLogFile(ctx context.Context, filename string) ([]LogInfo, error) {
 			}
 
 			resultsCh <- logs
-		}(part)
+		}(i, part)
 	}
 
 	go func() {
@@ -127,12 +261,22 @@ func ReadLogFile(ctx context.Context, filename string) ([]LogInfo, error) {
 		return nil, err
 	}
 
+	if cw != nil {
+		cw.finish()
+	}
+
 	return allLogs, nil
 }
 
 // processFilePart optimizes file reading with buffered I/O and preallocated slices.
-// Uses a scanner for efficient line-by-line processing, suitable for large files.
-func processFilePart(filename string, offset, size int64) ([]LogInfo, error) {
+// Uses a scanner driven by codec's SplitFunc and Decode for efficient, format-agnostic processing.
+// When report is non-nil, it's called periodically (every checkpointProgressLines records or
+// checkpointProgressPeriod, whichever comes first) with the chunk's current absolute file offset.
+// That offset tracks bytes consumed by records the SplitFunc has already returned, not raw bytes
+// read from the file: bufio.Scanner fills its internal buffer far ahead of the token it just
+// yielded, so using the reader's read count would report an offset past records that were never
+// actually decoded, and a resume from it would silently skip them.
+func processFilePart(filename string, offset, size int64, codec LogCodec, report func(absoluteOffset int64)) ([]LogInfo, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
@@ -143,28 +287,65 @@ func processFilePart(filename string, offset, size int64) ([]LogInfo, error) {
 		return nil, err
 	}
 
-	reader := io.LimitReader(file, size)
-	scanner := bufio.NewScanner(reader)
+	var consumed int64
+
+	scanner := bufio.NewScanner(io.LimitReader(file, size))
 	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(trackingSplitFunc(codec.SplitFunc(), &consumed))
 
 	logs := make([]LogInfo, 0, size/100)
 
+	sinceReport := 0
+	lastReport := time.Now()
+
 	for scanner.Scan() {
-		log, err := parseLogLine(scanner.Bytes())
+		log, err := codec.Decode(scanner.Bytes())
 		if err != nil {
-			continue // Skip invalid lines for robustness
+			continue // Skip invalid records for robustness
 		}
 
 		logs = append(logs, log)
+
+		if report == nil {
+			continue
+		}
+
+		sinceReport++
+
+		if sinceReport >= checkpointProgressLines || time.Since(lastReport) >= checkpointProgressPeriod {
+			report(offset + consumed)
+			sinceReport = 0
+			lastReport = time.Now()
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
 
+	if report != nil {
+		report(offset + consumed)
+	}
+
 	return logs, nil
 }
 
+// trackingSplitFunc wraps split, adding every advance it returns to *consumed.
+// advance is exactly the number of bytes split attributes to the record it
+// just returned (including any delimiter), so the running total reflects
+// only bytes belonging to records the scanner has actually yielded - unlike
+// the bytes read into the scanner's internal buffer, which runs far ahead.
+func trackingSplitFunc(split bufio.SplitFunc, consumed *int64) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		advance, token, err = split(data, atEOF)
+		if advance > 0 {
+			*consumed += int64(advance)
+		}
+
+		return advance, token, err
+	}
+}
+
 // parseLogLine uses byte-level operations for parsing, avoiding the overhead of encoding/json.
 // Tailored for the specific JSON structure: `{"userId": int, "pageName": string, "timestamp": ISO8601}`.
 // Finds field positions using byte operations, more efficient than string manipulations
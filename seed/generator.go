@@ -3,11 +3,12 @@ package seed
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/ashtishad/log-analyzer/processor"
 )
 
 // LogEntry represents a single log entry with user activity data.
@@ -17,29 +18,99 @@ type LogEntry struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Format selects the on-disk wire format GenerateLogFiles emits.
+type Format int
+
+const (
+	// FormatJSON emits newline-delimited JSON, decodable by processor.JSONCodec.
+	FormatJSON Format = iota
+	// FormatProtobuf emits length-prefixed protobuf records, decodable by
+	// processor.ProtobufCodec.
+	FormatProtobuf
+)
+
 const (
 	totalUsers        = 10000
 	totalEntries      = 10000
 	loyalCustomerRate = 0.18 // 18% loyal customers
 	minLoyalPages     = 4    // Minimum number of unique pages for loyal customers
+	dateLayout        = "2006-01-02"
 )
 
+// defaultPattern mirrors main's default -pattern flag, so GenerateLogFiles
+// and a default `go run .` agree on where files live without either side
+// having to know about the other's flags.
+var defaultPattern = filepath.Join("seed", "files", "logs_%Y-%m-%d.log")
+
 var pages = []string{
 	"home", "blog", "shop", "about", "contact", "profile", "dashboard",
 	"products", "services", "faq", "support", "news", "events", "gallery",
 	"forum", "reviews", "careers", "partners", "pricing", "testimonials",
 }
 
-// GenerateLogFiles creates two log files with simulated user activity data in log/slog format
-func GenerateLogFiles() error {
-	loyalCustomers := generateLoyalCustomers()
+// genConfig holds the options GenerateLogFiles is configured with.
+type genConfig struct {
+	format  Format
+	pattern string
+	from    time.Time
+	to      time.Time
+}
+
+// GenOption configures GenerateLogFiles.
+type GenOption func(*genConfig)
+
+// WithFormat selects the wire format GenerateLogFiles emits. Defaults to
+// FormatJSON when not supplied.
+func WithFormat(format Format) GenOption {
+	return func(c *genConfig) {
+		c.format = format
+	}
+}
+
+// WithPattern selects the strftime-style pattern (see processor.ExpandPattern)
+// GenerateLogFiles expands per day to decide each file's path. Defaults to
+// defaultPattern when not supplied.
+func WithPattern(pattern string) GenOption {
+	return func(c *genConfig) {
+		c.pattern = pattern
+	}
+}
+
+// WithDateRange selects the inclusive [from, to] range of days GenerateLogFiles
+// writes one file for. Defaults to 2024-10-01 through 2024-10-02 when not
+// supplied.
+func WithDateRange(from, to time.Time) GenOption {
+	return func(c *genConfig) {
+		c.from = from
+		c.to = to
+	}
+}
+
+// GenerateLogFiles creates one log file per day in its configured date range,
+// each with simulated user activity data.
+func GenerateLogFiles(opts ...GenOption) error {
+	cfg := &genConfig{
+		format:  FormatJSON,
+		pattern: defaultPattern,
+		from:    time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		to:      time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
-	if err := generateFile("logs_2024-10-01.log", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), loyalCustomers); err != nil {
-		return err
+	if cfg.to.Before(cfg.from) {
+		return fmt.Errorf("seed: invalid date range: %s is before %s", cfg.to.Format(dateLayout), cfg.from.Format(dateLayout))
 	}
 
-	if err := generateFile("logs_2024-10-02.log", time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC), loyalCustomers); err != nil {
-		return err
+	loyalCustomers := generateLoyalCustomers()
+
+	for d := cfg.from; !d.After(cfg.to); d = d.AddDate(0, 0, 1) {
+		path := processor.ExpandPattern(cfg.pattern, d)
+
+		if err := generateFile(path, d, loyalCustomers, cfg.format); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -62,19 +133,35 @@ func generateLoyalCustomers() map[int64]bool {
 // generateFile creates a log file with simulated user activity for a specific date.
 // Generates entries for loyal customers, loyal customer has 6-6 pages visits
 // Then generate entries for non-loyal customers separately.
-func generateFile(filename string, date time.Time, loyalCustomers map[int64]bool) error {
-	dir := filepath.Join("seed", "files")
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+func generateFile(path string, date time.Time, loyalCustomers map[int64]bool, format Format) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
 		return fmt.Errorf("error creating directory: %w", err)
 	}
 
-	file, err := os.Create(filepath.Join(dir, filename))
+	file, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("error creating file: %w", err)
 	}
 	defer file.Close()
 
-	logger := log.New(file, "", 0)
+	writeEntry := func(userID int64, page string, timestamp time.Time) error {
+		switch format {
+		case FormatProtobuf:
+			_, err := file.Write(processor.EncodeLogEntry(userID, page, timestamp))
+			return err
+		default:
+			entry := LogEntry{UserID: userID, PageName: page, Timestamp: timestamp}
+
+			jsonEntry, err := json.Marshal(entry)
+			if err != nil {
+				return fmt.Errorf("error marshaling log entry: %w", err)
+			}
+
+			_, err = file.Write(append(jsonEntry, '\n'))
+
+			return err
+		}
+	}
 
 	entriesGenerated := 0
 
@@ -83,19 +170,12 @@ func generateFile(filename string, date time.Time, loyalCustomers map[int64]bool
 		userPages := getUniquePages(pages, pageCount)
 
 		for _, page := range userPages {
-			entry := LogEntry{
-				UserID:    userID,
-				PageName:  page,
-				Timestamp: date.Add(time.Duration(rand.Intn(86400)) * time.Second),
-			}
+			timestamp := date.Add(time.Duration(rand.Intn(86400)) * time.Second)
 
-			jsonEntry, err := json.Marshal(entry)
-			if err != nil {
-				return fmt.Errorf("error marshaling log entry: %w", err)
+			if err := writeEntry(userID, page, timestamp); err != nil {
+				return err
 			}
 
-			logger.Println(string(jsonEntry))
-
 			entriesGenerated++
 		}
 	}
@@ -111,20 +191,12 @@ func generateFile(filename string, date time.Time, loyalCustomers map[int64]bool
 	for entriesGenerated < totalEntries {
 		userID := nonLoyalUsers[rand.Intn(len(nonLoyalUsers))]
 		page := pages[rand.Intn(len(pages))]
+		timestamp := date.Add(time.Duration(rand.Intn(86400)) * time.Second)
 
-		entry := LogEntry{
-			UserID:    userID,
-			PageName:  page,
-			Timestamp: date.Add(time.Duration(rand.Intn(86400)) * time.Second),
+		if err := writeEntry(userID, page, timestamp); err != nil {
+			return err
 		}
 
-		jsonEntry, err := json.Marshal(entry)
-		if err != nil {
-			return fmt.Errorf("error marshaling log entry: %w", err)
-		}
-
-		logger.Println(string(jsonEntry))
-
 		entriesGenerated++
 	}
 
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// slidingLoyaltyWindow is an incremental counterpart to getLoyalCustomers for
+// long-running processes, e.g. one consuming processor.Follow. It keeps only
+// the last windowDays calendar days of per-user page visits, so a process
+// that never stops doesn't have to retain unbounded history to keep
+// reporting loyal customers.
+type slidingLoyaltyWindow struct {
+	windowDays int
+	minDays    int
+	minPages   int
+
+	mu     sync.Mutex
+	slots  map[string]map[int64]map[string]bool // dayKey -> userID -> unique pages visited that day
+	latest time.Time                            // most recent day seen, zero value if none yet
+}
+
+// newSlidingLoyaltyWindow creates a window covering the last windowDays
+// calendar days.
+func newSlidingLoyaltyWindow(windowDays, minDays, minPages int) *slidingLoyaltyWindow {
+	return &slidingLoyaltyWindow{
+		windowDays: windowDays,
+		minDays:    minDays,
+		minPages:   minPages,
+		slots:      make(map[string]map[int64]map[string]bool),
+	}
+}
+
+// Add records a single visit. Visits must arrive in roughly chronological
+// order: advancing to a new, later calendar day evicts every slot that has
+// fallen more than windowDays-1 days behind it, including days with no
+// visits at all.
+func (w *slidingLoyaltyWindow) Add(userID int64, page string, at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dayKey := at.Format(dateLayout)
+
+	w.advanceTo(dayKey)
+
+	if w.slots[dayKey] == nil {
+		w.slots[dayKey] = make(map[int64]map[string]bool)
+	}
+
+	if w.slots[dayKey][userID] == nil {
+		w.slots[dayKey][userID] = make(map[string]bool)
+	}
+
+	w.slots[dayKey][userID][page] = true
+}
+
+// advanceTo records dayKey as the latest day seen, if it's later than the
+// current latest, and evicts every slot that's now more than windowDays-1
+// calendar days behind it. Computing eviction from each slot's actual date
+// rather than stepping a ring index means a day with zero visits still
+// falls out of the window on schedule.
+func (w *slidingLoyaltyWindow) advanceTo(dayKey string) {
+	day, err := time.Parse(dateLayout, dayKey)
+	if err != nil {
+		return
+	}
+
+	if !w.latest.IsZero() && !day.After(w.latest) {
+		return
+	}
+
+	w.latest = day
+
+	for k := range w.slots {
+		slotDay, err := time.Parse(dateLayout, k)
+		if err != nil {
+			continue
+		}
+
+		if int(w.latest.Sub(slotDay).Hours()/24) >= w.windowDays {
+			delete(w.slots, k)
+		}
+	}
+}
+
+// LoyalCustomers returns, in ascending order, users seen on at least minDays
+// distinct days within the window who visited at least minPages unique pages
+// across it.
+func (w *slidingLoyaltyWindow) LoyalCustomers() []int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	type userStats struct {
+		days  map[string]bool
+		pages map[string]bool
+	}
+
+	users := make(map[int64]*userStats)
+
+	for dayKey, slot := range w.slots {
+		for userID, pages := range slot {
+			stats, ok := users[userID]
+			if !ok {
+				stats = &userStats{days: make(map[string]bool), pages: make(map[string]bool)}
+				users[userID] = stats
+			}
+
+			stats.days[dayKey] = true
+
+			for page := range pages {
+				stats.pages[page] = true
+			}
+		}
+	}
+
+	loyalCustomers := make([]int64, 0)
+
+	for userID, stats := range users {
+		if len(stats.days) >= w.minDays && len(stats.pages) >= w.minPages {
+			loyalCustomers = append(loyalCustomers, userID)
+		}
+	}
+
+	sort.Slice(loyalCustomers, func(i, j int) bool {
+		return loyalCustomers[i] < loyalCustomers[j]
+	})
+
+	return loyalCustomers
+}
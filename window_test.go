@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSlidingLoyaltyWindow(t *testing.T) {
+	day1 := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day1.AddDate(0, 0, 2)
+
+	w := newSlidingLoyaltyWindow(2, minLoyalDays, minLoyalPages)
+
+	// User 1 is loyal on days 1-2, but day1 falls out of a 2-day window
+	// once day3 arrives, so it should drop out of the result.
+	for _, page := range []string{"blog", "shop", "about", "dashboard"} {
+		w.Add(1, page, day1)
+	}
+	for _, page := range []string{"blog", "shop", "about", "dashboard"} {
+		w.Add(1, page, day2)
+	}
+
+	if got, want := w.LoyalCustomers(), []int64{1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoyalCustomers() = %v, want %v", got, want)
+	}
+
+	for _, page := range []string{"blog", "shop"} {
+		w.Add(2, page, day3)
+	}
+
+	if got, want := w.LoyalCustomers(), []int64{}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after rotation, LoyalCustomers() = %v, want %v (user 1's day1 data should have been evicted)", got, want)
+	}
+}
+
+// TestSlidingLoyaltyWindowSkippedDay covers a day with zero visits, which a
+// ring index that steps by one slot per Add call (rather than by actual
+// calendar distance) would fail to evict on schedule.
+func TestSlidingLoyaltyWindowSkippedDay(t *testing.T) {
+	day1 := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	day3 := day1.AddDate(0, 0, 2) // day2 is skipped entirely: no Add call touches it
+
+	w := newSlidingLoyaltyWindow(2, 1, 1)
+
+	w.Add(1, "blog", day1)
+	w.Add(2, "blog", day3)
+
+	// A true 2-day window ending on day3 covers days 2-3. User 1's only
+	// visit was on day1, which is now 2 days behind day3 and must have
+	// fallen out of the window even though no Add call ever touched day2.
+	if got, want := w.LoyalCustomers(), []int64{2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoyalCustomers() = %v, want %v (user 1's day1 data should have been evicted across the skipped day)", got, want)
+	}
+}